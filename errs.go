@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sync/atomic"
 )
 
 // return code.
@@ -95,6 +96,46 @@ const (
 	Success = "success"
 )
 
+// Sentinel errors, one per RetXxx code, so callers can write
+// errors.Is(err, errs.ErrClientTimeout) instead of manually inspecting
+// Code(err). Matching is by Code, and also by Type for sentinels that carry
+// one; see (*Error).Is.
+var (
+	ErrServerDecodeFail      = &Error{Type: ErrorTypeFramework, Code: RetServerDecodeFail, Msg: "server decode fail"}
+	ErrServerEncodeFail      = &Error{Type: ErrorTypeFramework, Code: RetServerEncodeFail, Msg: "server encode fail"}
+	ErrServerNoService       = &Error{Type: ErrorTypeFramework, Code: RetServerNoService, Msg: "server no service"}
+	ErrServerNoFunc          = &Error{Type: ErrorTypeFramework, Code: RetServerNoFunc, Msg: "server no func"}
+	ErrServerTimeout         = &Error{Type: ErrorTypeFramework, Code: RetServerTimeout, Msg: "server timeout"}
+	ErrServerOverload        = &Error{Type: ErrorTypeFramework, Code: RetServerOverload, Msg: "server overload"}
+	ErrServerThrottled       = &Error{Type: ErrorTypeFramework, Code: RetServerThrottled, Msg: "server throttled"}
+	ErrServerFullLinkTimeout = &Error{Type: ErrorTypeFramework, Code: RetServerFullLinkTimeout, Msg: "server full link timeout"}
+	ErrServerSystemErr       = &Error{Type: ErrorTypeFramework, Code: RetServerSystemErr, Msg: "server system error"}
+	ErrServerAuthFail        = &Error{Type: ErrorTypeFramework, Code: RetServerAuthFail, Msg: "server auth fail"}
+	ErrServerValidateFail    = &Error{Type: ErrorTypeFramework, Code: RetServerValidateFail, Msg: "server validate fail"}
+
+	ErrClientTimeout         = &Error{Type: ErrorTypeFramework, Code: RetClientTimeout, Msg: "client timeout"}
+	ErrClientFullLinkTimeout = &Error{Type: ErrorTypeFramework, Code: RetClientFullLinkTimeout, Msg: "client full link timeout"}
+	ErrClientConnectFail     = &Error{Type: ErrorTypeFramework, Code: RetClientConnectFail, Msg: "client connect fail"}
+	ErrClientEncodeFail      = &Error{Type: ErrorTypeFramework, Code: RetClientEncodeFail, Msg: "client encode fail"}
+	ErrClientDecodeFail      = &Error{Type: ErrorTypeFramework, Code: RetClientDecodeFail, Msg: "client decode fail"}
+	ErrClientThrottled       = &Error{Type: ErrorTypeFramework, Code: RetClientThrottled, Msg: "client throttled"}
+	ErrClientOverload        = &Error{Type: ErrorTypeFramework, Code: RetClientOverload, Msg: "client overload"}
+	ErrClientRouteErr        = &Error{Type: ErrorTypeFramework, Code: RetClientRouteErr, Msg: "client route error"}
+	ErrClientNetErr          = &Error{Type: ErrorTypeFramework, Code: RetClientNetErr, Msg: "client net error"}
+	ErrClientValidateFail    = &Error{Type: ErrorTypeFramework, Code: RetClientValidateFail, Msg: "client validate fail"}
+	ErrClientCanceled        = &Error{Type: ErrorTypeFramework, Code: RetClientCanceled, Msg: "client canceled"}
+	ErrClientReadFrameErr    = &Error{Type: ErrorTypeFramework, Code: RetClientReadFrameErr, Msg: "client read frame error"}
+	ErrClientStreamQueueFull = &Error{Type: ErrorTypeFramework, Code: RetClientStreamQueueFull, Msg: "client stream queue full"}
+	ErrClientStreamReadEnd   = &Error{Type: ErrorTypeFramework, Code: RetClientStreamReadEnd, Msg: "client stream read end"}
+
+	// ErrInvalidArgument, ErrNotFound and ErrUnknown are generic codes used by
+	// both framework and business errors, so they carry no Type and match
+	// any error with the same Code regardless of Type.
+	ErrInvalidArgument = &Error{Code: RetInvalidArgument, Msg: "invalid argument"}
+	ErrNotFound        = &Error{Code: RetNotFound, Msg: "not found"}
+	ErrUnknown         = &Error{Code: RetUnknown, Msg: "unknown error"}
+)
+
 // Error is the error code structure which contains error code type and error message.
 type Error struct {
 	Type int
@@ -102,8 +143,9 @@ type Error struct {
 	Msg  string
 	Desc string
 
-	cause error      // internal error, form the error chain.
-	stack stackTrace // call stack, if the error chain already has a stack, it will not be set.
+	cause   error         // internal error, form the error chain.
+	stack   stackTrace    // call stack, if the error chain already has a stack, it will not be set.
+	details []interface{} // structured payloads attached via WithDetail, e.g. retry info or a validation report.
 }
 
 // Error implements the error interface and returns the error description.
@@ -122,10 +164,16 @@ func (e *Error) Error() string {
 // Format implements the fmt.Formatter interface.
 func (e *Error) Format(s fmt.State, verb rune) {
 	var stackTrace stackTrace
+	var printDetails bool
 	defer func() {
 		if stackTrace != nil {
 			stackTrace.Format(s, verb)
 		}
+		if printDetails {
+			for _, d := range e.details {
+				_, _ = fmt.Fprintf(s, "\nDetail: %+v", d)
+			}
+		}
 	}()
 	switch verb {
 	case 'v':
@@ -137,6 +185,7 @@ func (e *Error) Format(s fmt.State, verb rune) {
 			if e.cause != nil {
 				_, _ = fmt.Fprintf(s, "\nCause by %+v", e.cause)
 			}
+			printDetails = true
 			return
 		}
 		_, _ = io.WriteString(s, e.Error())
@@ -158,6 +207,25 @@ func (e *Error) Unwrap() error {
 	return e.cause
 }
 
+// Is implements the errors.Is interface. It reports whether target is an
+// *Error with the same Code, regardless of Msg, cause or stack. If target's
+// Type is nonzero, the Type must also match; this lets the generic sentinels
+// (ErrInvalidArgument, ErrNotFound, ErrUnknown), which carry no Type, match
+// errors of either type while the rest match only their declared Type.
+func (e *Error) Is(target error) bool {
+	if e == nil {
+		return target == nil
+	}
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	if e.Code != t.Code {
+		return false
+	}
+	return t.Type == 0 || e.Type == t.Type
+}
+
 // IsTimeout checks whether this error is a timeout error with error type typ.
 func (e *Error) IsTimeout(typ int) bool {
 	return e.Type == typ &&
@@ -167,6 +235,75 @@ func (e *Error) IsTimeout(typ int) bool {
 			e.Code == RetServerFullLinkTimeout)
 }
 
+// WithDetail attaches a structured payload (retry info, quota failure, a
+// localized message, a request ID, ...) to e and returns e for chaining.
+func (e *Error) WithDetail(detail interface{}) *Error {
+	if e == nil {
+		return e
+	}
+	e.details = append(e.details, detail)
+	return e
+}
+
+// Details returns the payloads attached to e via WithDetail, followed by
+// those of every *Error in its cause chain, outermost first.
+func (e *Error) Details() []interface{} {
+	if e == nil {
+		return nil
+	}
+	details := append([]interface{}{}, e.OwnDetails()...)
+	var cause *Error
+	if errors.As(e.cause, &cause) {
+		details = append(details, cause.Details()...)
+	}
+	return details
+}
+
+// OwnDetails returns only the payloads attached directly to e via
+// WithDetail, without walking into its cause chain. Codecs that reconstruct
+// the chain node by node, such as errspb, must use this instead of Details
+// to avoid re-attributing an inner error's details to every outer node.
+func (e *Error) OwnDetails() []interface{} {
+	if e == nil {
+		return nil
+	}
+	return append([]interface{}{}, e.details...)
+}
+
+// hooks holds the currently registered observability hooks. It is read on
+// every error construction, so it is swapped atomically rather than guarded
+// by a mutex, keeping the zero-hook path allocation-free.
+var hooks atomic.Pointer[[]func(*Error)]
+
+// RegisterHook adds a hook that is invoked, in registration order, whenever
+// New, Newf, Wrap, Wrapf, NewFrameError, NewCalleeFrameError or
+// WrapFrameError mints a new *Error. Hooks are typically installed once at
+// process start to emit metrics, push tracing span events, or log
+// structured records; see the errs/metrics subpackage for a ready-made one.
+func RegisterHook(hook func(*Error)) {
+	for {
+		old := hooks.Load()
+		var updated []func(*Error)
+		if old != nil {
+			updated = append(updated, (*old)...)
+		}
+		updated = append(updated, hook)
+		if hooks.CompareAndSwap(old, &updated) {
+			return
+		}
+	}
+}
+
+func runHooks(err *Error) {
+	registered := hooks.Load()
+	if registered == nil {
+		return
+	}
+	for _, hook := range *registered {
+		hook(err)
+	}
+}
+
 // New creates an error, which defaults to the business error type to improve business development efficiency.
 func New(code int, msg string) error {
 	err := &Error{
@@ -177,6 +314,7 @@ func New(code int, msg string) error {
 	if traceable {
 		err.stack = callers()
 	}
+	runHooks(err)
 	return err
 }
 
@@ -191,6 +329,7 @@ func Newf(code int, format string, params ...interface{}) error {
 	if traceable {
 		err.stack = callers()
 	}
+	runHooks(err)
 	return err
 }
 
@@ -212,6 +351,7 @@ func Wrap(err error, code int, msg string) error {
 	if traceable && !errors.As(err, &e) {
 		wrapErr.stack = callers()
 	}
+	runHooks(wrapErr)
 	return wrapErr
 }
 
@@ -232,6 +372,7 @@ func Wrapf(err error, code int, format string, params ...interface{}) error {
 	if traceable && !errors.As(err, &e) {
 		wrapErr.stack = callers()
 	}
+	runHooks(wrapErr)
 	return wrapErr
 }
 
@@ -246,6 +387,7 @@ func NewFrameError(code int, msg string) error {
 	if traceable {
 		err.stack = callers()
 	}
+	runHooks(err)
 	return err
 }
 
@@ -260,6 +402,7 @@ func NewCalleeFrameError(code int, msg string) error {
 	if traceable {
 		err.stack = callers()
 	}
+	runHooks(err)
 	return err
 }
 
@@ -280,9 +423,18 @@ func WrapFrameError(err error, code int, msg string) error {
 	if traceable && !errors.As(err, &e) {
 		wrapErr.stack = callers()
 	}
+	runHooks(wrapErr)
 	return wrapErr
 }
 
+// FromWire reconstructs an *Error with every field set explicitly,
+// including its cause. It exists for wire-format codecs, such as errspb,
+// that must rebuild an error with an arbitrary Type/cause combination the
+// regular constructors don't expose (they each pin Type to one value).
+func FromWire(typ int, code int32, msg, desc string, cause error) *Error {
+	return &Error{Type: typ, Code: code, Msg: msg, Desc: desc, cause: cause}
+}
+
 // Code gets the error code through error.
 func Code(e error) int {
 	if e == nil {
@@ -324,3 +476,21 @@ func Msg(e error) string {
 // Cause returns the internal error.
 // Deprecated: use Unwrap instead.
 func (e *Error) Cause() error { return e.Unwrap() }
+
+// Detail walks err's cause chain and returns the first attached detail
+// (see Error.WithDetail) that matches type T.
+func Detail[T any](err error) (T, bool) {
+	var zero T
+	for err != nil {
+		var e *Error
+		if errors.As(err, &e) {
+			for _, d := range e.details {
+				if v, ok := d.(T); ok {
+					return v, true
+				}
+			}
+		}
+		err = errors.Unwrap(err)
+	}
+	return zero, false
+}
@@ -332,6 +332,142 @@ func TestNilErrorUnwrap(t *testing.T) {
 	require.False(t, errors.Is(err, errors.New("some error")))
 }
 
+func TestRegisterHook(t *testing.T) {
+	var got []*errs.Error
+	errs.RegisterHook(func(e *errs.Error) {
+		got = append(got, e)
+	})
+
+	e := errs.New(111, "inner fail")
+	require.Len(t, got, 1)
+	assert.Same(t, e, got[0])
+
+	e = errs.Wrap(e, 222, "wrap err")
+	require.Len(t, got, 2)
+	assert.Same(t, e, got[1])
+
+	errs.Wrap(nil, 222, "wrap err")
+	require.Len(t, got, 2)
+}
+
+func TestErrorIs(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		want   bool
+	}{
+		{
+			name:   "exact sentinel match",
+			err:    errs.NewFrameError(int(errs.RetClientTimeout), "dial timed out"),
+			target: errs.ErrClientTimeout,
+			want:   true,
+		},
+		{
+			name:   "wrapped sentinel match",
+			err:    fmt.Errorf("rpc failed: %w", errs.NewFrameError(int(errs.RetClientTimeout), "dial timed out")),
+			target: errs.ErrClientTimeout,
+			want:   true,
+		},
+		{
+			name:   "type mismatch",
+			err:    errs.NewFrameError(int(errs.RetServerTimeout), "queue timeout"),
+			target: errs.New(int(errs.RetServerTimeout), "queue timeout").(*errs.Error),
+			want:   false,
+		},
+		{
+			name:   "generic sentinel matches any type",
+			err:    errs.NewFrameError(int(errs.RetNotFound), "missing"),
+			target: errs.ErrNotFound,
+			want:   true,
+		},
+		{
+			name:   "code mismatch",
+			err:    errs.NewFrameError(int(errs.RetClientTimeout), "dial timed out"),
+			target: errs.ErrServerTimeout,
+			want:   false,
+		},
+		{
+			name:   "nil error",
+			err:    nil,
+			target: errs.ErrClientTimeout,
+			want:   false,
+		},
+		{
+			name:   "non-*Error leaf",
+			err:    errors.New("plain error"),
+			target: errs.ErrClientTimeout,
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, errors.Is(tt.err, tt.target))
+		})
+	}
+}
+
+type retryInfo struct {
+	RetryAfterMs int
+}
+
+type requestID string
+
+func TestWithDetail(t *testing.T) {
+	err := errs.New(111, "inner fail").(*errs.Error).
+		WithDetail(retryInfo{RetryAfterMs: 100}).
+		WithDetail(requestID("req-1"))
+
+	ri, ok := errs.Detail[retryInfo](err)
+	assert.True(t, ok)
+	assert.Equal(t, 100, ri.RetryAfterMs)
+
+	rid, ok := errs.Detail[requestID](err)
+	assert.True(t, ok)
+	assert.Equal(t, requestID("req-1"), rid)
+
+	_, ok = errs.Detail[int](err)
+	assert.False(t, ok)
+}
+
+func TestDetailOnlyPrintedForPlusV(t *testing.T) {
+	err := errs.New(111, "inner fail").(*errs.Error).WithDetail(retryInfo{RetryAfterMs: 100})
+
+	assert.NotContains(t, fmt.Sprintf("%v", err), "Detail:")
+	assert.NotContains(t, fmt.Sprintf("%s", err), "Detail:")
+	assert.NotContains(t, fmt.Sprintf("%q", err), "Detail:")
+
+	s := fmt.Sprintf("%+v", err)
+	assert.Contains(t, s, "Detail:")
+	// the detail line comes after the stack, which itself comes after the message.
+	msgIdx := strings.Index(s, "type:business, code:111, msg:inner fail")
+	detailIdx := strings.Index(s, "Detail:")
+	require.GreaterOrEqual(t, msgIdx, 0)
+	require.Greater(t, detailIdx, msgIdx)
+}
+
+func TestDetailSurvivesWrap(t *testing.T) {
+	inner := errs.New(111, "inner fail").(*errs.Error).WithDetail(retryInfo{RetryAfterMs: 50})
+	outer := errs.Wrap(inner, 222, "wrap err").(*errs.Error).WithDetail(requestID("req-2"))
+
+	assert.Equal(t, []interface{}{requestID("req-2"), retryInfo{RetryAfterMs: 50}}, outer.Details())
+
+	ri, ok := errs.Detail[retryInfo](outer)
+	assert.True(t, ok)
+	assert.Equal(t, 50, ri.RetryAfterMs)
+
+	assert.Equal(t, []interface{}{requestID("req-2")}, outer.OwnDetails())
+	var cause *errs.Error
+	assert.True(t, errors.As(outer.Unwrap(), &cause))
+	assert.Equal(t, []interface{}{retryInfo{RetryAfterMs: 50}}, cause.OwnDetails())
+}
+
+func TestDetailNilError(t *testing.T) {
+	var err *errs.Error
+	assert.Nil(t, err.Details())
+	assert.Same(t, err, err.WithDetail(retryInfo{}))
+}
+
 type testError struct {
 	Err error
 }
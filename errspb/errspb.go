@@ -0,0 +1,232 @@
+// Package errspb implements the wire format described by errs.proto,
+// letting an *errs.Error cross any transport (trpc, gRPC metadata trailers,
+// message queues, ...) without losing its Type, Code, Desc, details or
+// cause chain.
+//
+// The message fields are encoded with the standard protobuf wire format
+// (see google.golang.org/protobuf/encoding/protowire) so the bytes this
+// package produces match what protoc-gen-go would generate from errs.proto.
+// Detail payloads (field 6, "repeated bytes") are gob-encoded: there is no
+// proto descriptor for an arbitrary Go value attached via Error.WithDetail,
+// so a real google.protobuf.Any (which requires a registered message type
+// per detail) is not usable here. Callers that need cross-language detail
+// support should migrate their detail types to generated proto messages and
+// carry them out-of-band. Stack frames are not propagated: Error's stack is
+// internal to the errs package and has no exported accessor, so errs.proto
+// has no field for it.
+package errspb
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+
+	"github.com/HildaM/errs"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Marshal flattens err's entire Unwrap chain into nested causes and encodes
+// it using the errs.proto wire format. A non-*errs.Error node in the chain
+// is preserved as Error{Type: ErrorTypeBusiness, Code: RetUnknown, Msg:
+// err.Error()}, with whatever it wraps encoded as its cause.
+func Marshal(err error) ([]byte, error) {
+	if err == nil {
+		return nil, nil
+	}
+	msg, encErr := toWireError(err)
+	if encErr != nil {
+		return nil, encErr
+	}
+	return encode(msg), nil
+}
+
+// Unmarshal reconstructs the error chain encoded by Marshal.
+func Unmarshal(data []byte) (error, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	msg, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+	return fromWireError(msg), nil
+}
+
+// wireError mirrors the errs.proto Error message.
+type wireError struct {
+	Type    int32
+	Code    int32
+	Msg     string
+	Desc    string
+	Causes  []*wireError
+	Details [][]byte // gob-encoded, see package doc.
+}
+
+func toWireError(err error) (*wireError, error) {
+	e, ok := err.(*errs.Error)
+	if !ok {
+		msg := &wireError{Type: errs.ErrorTypeBusiness, Code: int32(errs.RetUnknown), Msg: err.Error()}
+		if cause := errors.Unwrap(err); cause != nil {
+			causeMsg, cerr := toWireError(cause)
+			if cerr != nil {
+				return nil, cerr
+			}
+			msg.Causes = []*wireError{causeMsg}
+		}
+		return msg, nil
+	}
+
+	details, derr := encodeDetails(e.OwnDetails())
+	if derr != nil {
+		return nil, derr
+	}
+
+	msg := &wireError{
+		Type:    int32(e.Type),
+		Code:    e.Code,
+		Msg:     e.Msg,
+		Desc:    e.Desc,
+		Details: details,
+	}
+	if cause := e.Unwrap(); cause != nil {
+		causeMsg, err := toWireError(cause)
+		if err != nil {
+			return nil, err
+		}
+		msg.Causes = []*wireError{causeMsg}
+	}
+	return msg, nil
+}
+
+func fromWireError(msg *wireError) error {
+	var cause error
+	if len(msg.Causes) > 0 {
+		cause = fromWireError(msg.Causes[0])
+	}
+	e := errs.FromWire(int(msg.Type), msg.Code, msg.Msg, msg.Desc, cause)
+	for _, d := range decodeDetails(msg.Details) {
+		e.WithDetail(d)
+	}
+	return e
+}
+
+func encodeDetails(details []interface{}) ([][]byte, error) {
+	if len(details) == 0 {
+		return nil, nil
+	}
+	encoded := make([][]byte, 0, len(details))
+	for _, d := range details {
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(&d); err != nil {
+			return nil, fmt.Errorf("errspb: encode detail: %w", err)
+		}
+		encoded = append(encoded, buf.Bytes())
+	}
+	return encoded, nil
+}
+
+func decodeDetails(raw [][]byte) []interface{} {
+	details := make([]interface{}, 0, len(raw))
+	for _, b := range raw {
+		var d interface{}
+		if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&d); err != nil {
+			continue
+		}
+		details = append(details, d)
+	}
+	return details
+}
+
+// encode serializes msg using the protobuf wire format for errs.proto's
+// Error message (tags 1-4 and 5 for nested causes; details are carried as
+// length-delimited blobs under tag 6).
+func encode(msg *wireError) []byte {
+	var b []byte
+	if msg.Type != 0 {
+		b = protowire.AppendTag(b, 1, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(int64(msg.Type)))
+	}
+	if msg.Code != 0 {
+		b = protowire.AppendTag(b, 2, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(int64(msg.Code)))
+	}
+	if msg.Msg != "" {
+		b = protowire.AppendTag(b, 3, protowire.BytesType)
+		b = protowire.AppendString(b, msg.Msg)
+	}
+	if msg.Desc != "" {
+		b = protowire.AppendTag(b, 4, protowire.BytesType)
+		b = protowire.AppendString(b, msg.Desc)
+	}
+	for _, cause := range msg.Causes {
+		b = protowire.AppendTag(b, 5, protowire.BytesType)
+		b = protowire.AppendBytes(b, encode(cause))
+	}
+	for _, d := range msg.Details {
+		b = protowire.AppendTag(b, 6, protowire.BytesType)
+		b = protowire.AppendBytes(b, d)
+	}
+	return b
+}
+
+func decode(data []byte) (*wireError, error) {
+	msg := &wireError{}
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		data = data[n:]
+
+		switch num {
+		case 1, 2:
+			v, n := protowire.ConsumeVarint(data)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			data = data[n:]
+			if num == 1 {
+				msg.Type = int32(v)
+			} else {
+				msg.Code = int32(v)
+			}
+		case 3, 4:
+			v, n := protowire.ConsumeString(data)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			data = data[n:]
+			if num == 3 {
+				msg.Msg = v
+			} else {
+				msg.Desc = v
+			}
+		case 5:
+			v, n := protowire.ConsumeBytes(data)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			data = data[n:]
+			cause, err := decode(v)
+			if err != nil {
+				return nil, err
+			}
+			msg.Causes = append(msg.Causes, cause)
+		case 6:
+			v, n := protowire.ConsumeBytes(data)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			data = data[n:]
+			msg.Details = append(msg.Details, append([]byte(nil), v...))
+		default:
+			n := protowire.ConsumeFieldValue(num, typ, data)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			data = data[n:]
+		}
+	}
+	return msg, nil
+}
@@ -0,0 +1,85 @@
+package errspb_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/HildaM/errs"
+	"github.com/HildaM/errs/errspb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalUnmarshalRoundTrip(t *testing.T) {
+	inner := errs.NewFrameError(111, "inner fail").(*errs.Error)
+	inner.Desc = "rpc framework"
+	outer := errs.Wrap(inner, 222, "outer fail").(*errs.Error)
+
+	data, err := errspb.Marshal(outer)
+	require.NoError(t, err)
+
+	got, err := errspb.Unmarshal(data)
+	require.NoError(t, err)
+
+	var gotErr *errs.Error
+	require.True(t, errors.As(got, &gotErr))
+	assert.Equal(t, errs.ErrorTypeBusiness, gotErr.Type)
+	assert.EqualValues(t, 222, gotErr.Code)
+	assert.Equal(t, "outer fail", gotErr.Msg)
+
+	var cause *errs.Error
+	require.True(t, errors.As(gotErr.Unwrap(), &cause))
+	assert.Equal(t, errs.ErrorTypeFramework, cause.Type)
+	assert.EqualValues(t, 111, cause.Code)
+	assert.Equal(t, "inner fail", cause.Msg)
+	assert.Equal(t, "rpc framework", cause.Desc)
+}
+
+func TestMarshalUnmarshalDetailsPerNode(t *testing.T) {
+	inner := errs.NewFrameError(111, "inner fail").(*errs.Error).WithDetail("inner-detail")
+	outer := errs.Wrap(inner, 222, "outer fail").(*errs.Error).WithDetail("outer-detail")
+
+	data, err := errspb.Marshal(outer)
+	require.NoError(t, err)
+
+	got, err := errspb.Unmarshal(data)
+	require.NoError(t, err)
+
+	var gotOuter *errs.Error
+	require.True(t, errors.As(got, &gotOuter))
+	assert.Equal(t, []interface{}{"outer-detail"}, gotOuter.OwnDetails())
+
+	var gotInner *errs.Error
+	require.True(t, errors.As(gotOuter.Unwrap(), &gotInner))
+	assert.Equal(t, []interface{}{"inner-detail"}, gotInner.OwnDetails())
+
+	// Details() aggregates the chain, so the duplication bug would show up
+	// here as ["outer-detail", "inner-detail", "inner-detail"].
+	assert.Equal(t, []interface{}{"outer-detail", "inner-detail"}, gotOuter.Details())
+}
+
+func TestMarshalNonErrorLeaf(t *testing.T) {
+	outer := errs.Wrap(errors.New("plain error"), 222, "outer fail")
+
+	data, err := errspb.Marshal(outer)
+	require.NoError(t, err)
+
+	got, err := errspb.Unmarshal(data)
+	require.NoError(t, err)
+
+	var leaf *errs.Error
+	require.True(t, errors.As(errors.Unwrap(got), &leaf))
+	assert.Equal(t, errs.ErrorTypeBusiness, leaf.Type)
+	assert.EqualValues(t, errs.RetUnknown, leaf.Code)
+	assert.Equal(t, "plain error", leaf.Msg)
+}
+
+func TestMarshalNil(t *testing.T) {
+	data, err := errspb.Marshal(nil)
+	require.NoError(t, err)
+	assert.Nil(t, data)
+
+	got, err := errspb.Unmarshal(nil)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
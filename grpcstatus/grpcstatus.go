@@ -0,0 +1,182 @@
+// Package grpcstatus converts between this package's *errs.Error and the
+// canonical gRPC status representation, so services built on errs can
+// interoperate with gRPC middleware and propagate structured errors over the
+// wire.
+package grpcstatus
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/HildaM/errs"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	grpcstatuspkg "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+var mappingMu sync.RWMutex
+
+// defaultRetToCode is the built-in mapping from this package's Ret codes to
+// canonical gRPC codes. It is seeded once and then consulted (and possibly
+// overridden) through RegisterGRPCCodeMapping.
+var defaultRetToCode = map[int]codes.Code{
+	errs.RetOK:                    codes.OK,
+	errs.RetServerDecodeFail:      codes.Internal,
+	errs.RetServerEncodeFail:      codes.Internal,
+	errs.RetServerNoService:       codes.Unimplemented,
+	errs.RetServerNoFunc:          codes.Unimplemented,
+	errs.RetServerTimeout:         codes.DeadlineExceeded,
+	errs.RetServerOverload:        codes.ResourceExhausted,
+	errs.RetServerThrottled:       codes.ResourceExhausted,
+	errs.RetServerFullLinkTimeout: codes.DeadlineExceeded,
+	errs.RetServerSystemErr:       codes.Internal,
+	errs.RetServerAuthFail:        codes.Unauthenticated,
+	errs.RetServerValidateFail:    codes.InvalidArgument,
+	errs.RetClientTimeout:         codes.DeadlineExceeded,
+	errs.RetClientFullLinkTimeout: codes.DeadlineExceeded,
+	errs.RetClientConnectFail:     codes.Unavailable,
+	errs.RetClientEncodeFail:      codes.Internal,
+	errs.RetClientDecodeFail:      codes.Internal,
+	errs.RetClientThrottled:       codes.ResourceExhausted,
+	errs.RetClientOverload:        codes.ResourceExhausted,
+	errs.RetClientRouteErr:        codes.Unavailable,
+	errs.RetClientNetErr:          codes.Unavailable,
+	errs.RetClientValidateFail:    codes.InvalidArgument,
+	errs.RetClientCanceled:        codes.Canceled,
+	errs.RetClientReadFrameErr:    codes.Internal,
+	errs.RetClientStreamQueueFull: codes.ResourceExhausted,
+	errs.RetClientStreamReadEnd:   codes.Unavailable,
+	errs.RetInvalidArgument:       codes.InvalidArgument,
+	errs.RetNotFound:              codes.NotFound,
+	errs.RetUnknown:               codes.Unknown,
+}
+
+// codeToRet is the reverse of defaultRetToCode, used by FromGRPCStatus when
+// no detail payload is present to recover the exact Ret code from.
+var codeToRet = reverse(defaultRetToCode)
+
+func reverse(m map[int]codes.Code) map[codes.Code]int {
+	// Map iteration order is randomized, so insert in ascending key order:
+	// the lowest-valued Ret code for a given gRPC code is then deterministically
+	// preferred when several Ret codes collide (e.g. codes.Internal has several
+	// framework-side sources).
+	rets := make([]int, 0, len(m))
+	for ret := range m {
+		rets = append(rets, ret)
+	}
+	sort.Ints(rets)
+
+	r := make(map[codes.Code]int, len(m))
+	for _, ret := range rets {
+		code := m[ret]
+		if _, ok := r[code]; !ok {
+			r[code] = ret
+		}
+	}
+	return r
+}
+
+// RegisterGRPCCodeMapping overrides (or adds) the gRPC code that ret maps to,
+// so business codes can be slotted into the table alongside the built-in
+// framework codes.
+func RegisterGRPCCodeMapping(ret int, code codes.Code) {
+	mappingMu.Lock()
+	defer mappingMu.Unlock()
+	defaultRetToCode[ret] = code
+	codeToRet[code] = ret
+}
+
+func grpcCode(ret int) codes.Code {
+	mappingMu.RLock()
+	defer mappingMu.RUnlock()
+	if code, ok := defaultRetToCode[ret]; ok {
+		return code
+	}
+	return codes.Unknown
+}
+
+func retCode(code codes.Code) (int, bool) {
+	mappingMu.RLock()
+	defer mappingMu.RUnlock()
+	ret, ok := codeToRet[code]
+	return ret, ok
+}
+
+// ToGRPCStatus converts err into a *status.Status, carrying the original
+// errs.Error Type and Desc as a typed detail so FromGRPCStatus can
+// reconstruct them on the receiving side. If err is not an *errs.Error and
+// does not wrap one, it is reported as codes.Unknown with err.Error() as
+// the message.
+//
+// Only err's outermost *errs.Error is encoded: google.rpc.Status has no
+// notion of a cause chain, so any wrapped causes are lost on the wire. To
+// propagate a full chain, use errspb instead.
+func ToGRPCStatus(err error) *grpcstatuspkg.Status {
+	if err == nil {
+		return grpcstatuspkg.New(codes.OK, errs.Success)
+	}
+	var e *errs.Error
+	if !errors.As(err, &e) {
+		return grpcstatuspkg.New(codes.Unknown, err.Error())
+	}
+
+	info, ierr := anypb.New(&structpb.Struct{
+		Fields: map[string]*structpb.Value{
+			"type": structpb.NewNumberValue(float64(e.Type)),
+			"code": structpb.NewNumberValue(float64(e.Code)),
+			"desc": structpb.NewStringValue(e.Desc),
+		},
+	})
+	pb := &status.Status{
+		Code:    int32(grpcCode(int(e.Code))),
+		Message: e.Msg,
+	}
+	if ierr == nil {
+		pb.Details = []*anypb.Any{info}
+	}
+	return grpcstatuspkg.FromProto(pb)
+}
+
+// FromGRPCStatus reconstructs the outermost *errs.Error from s, preferring
+// the Type, Code and Desc carried in the detail payload attached by
+// ToGRPCStatus and falling back to the registered gRPC code mapping when no
+// such payload is present. Since ToGRPCStatus only encodes one node, the
+// returned error never has a cause.
+func FromGRPCStatus(s *grpcstatuspkg.Status) error {
+	if s == nil || s.Code() == codes.OK {
+		return nil
+	}
+	e := &errs.Error{
+		Type: errs.ErrorTypeBusiness,
+		Msg:  s.Message(),
+	}
+	if ret, ok := retCode(s.Code()); ok {
+		e.Code = int32(ret)
+	} else {
+		e.Code = int32(errs.RetUnknown)
+	}
+
+	for _, d := range s.Proto().GetDetails() {
+		var st structpb.Struct
+		if !d.MessageIs(&st) {
+			continue
+		}
+		if err := d.UnmarshalTo(&st); err != nil {
+			continue
+		}
+		if v, ok := st.Fields["type"]; ok {
+			e.Type = int(v.GetNumberValue())
+		}
+		if v, ok := st.Fields["code"]; ok {
+			e.Code = int32(v.GetNumberValue())
+		}
+		if v, ok := st.Fields["desc"]; ok {
+			e.Desc = v.GetStringValue()
+		}
+		break
+	}
+	return e
+}
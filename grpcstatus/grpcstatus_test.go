@@ -0,0 +1,66 @@
+package grpcstatus_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/HildaM/errs"
+	"github.com/HildaM/errs/grpcstatus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	grpcstatuspkg "google.golang.org/grpc/status"
+)
+
+func TestToFromGRPCStatusRoundTrip(t *testing.T) {
+	e := errs.New(errs.RetInvalidArgument, "bad arg").(*errs.Error)
+	e.Desc = "validation"
+
+	got := grpcstatus.FromGRPCStatus(grpcstatus.ToGRPCStatus(e))
+
+	var gotErr *errs.Error
+	require.ErrorAs(t, got, &gotErr)
+	assert.Equal(t, e.Type, gotErr.Type)
+	assert.Equal(t, e.Code, gotErr.Code)
+	assert.Equal(t, e.Msg, gotErr.Msg)
+	assert.Equal(t, e.Desc, gotErr.Desc)
+}
+
+func TestToGRPCStatusWrappedError(t *testing.T) {
+	inner := errs.New(errs.RetNotFound, "missing").(*errs.Error)
+	wrapped := fmt.Errorf("rpc failed: %w", inner)
+
+	s := grpcstatus.ToGRPCStatus(wrapped)
+	assert.Equal(t, codes.NotFound, s.Code())
+
+	got := grpcstatus.FromGRPCStatus(s)
+	var gotErr *errs.Error
+	require.ErrorAs(t, got, &gotErr)
+	assert.Equal(t, inner.Code, gotErr.Code)
+}
+
+func TestToGRPCStatusNonErrsError(t *testing.T) {
+	s := grpcstatus.ToGRPCStatus(fmt.Errorf("plain error"))
+	assert.Equal(t, codes.Unknown, s.Code())
+	assert.Equal(t, "plain error", s.Message())
+}
+
+func TestFromGRPCStatusDeterministicForCollidingCodes(t *testing.T) {
+	// Several Ret codes (1, 2, 31, 121, 122, 171) map to codes.Internal.
+	// Decoding a bare codes.Internal status (no errs detail) must always
+	// recover the same Ret code, not a random one of the colliding sources.
+	s := grpcstatuspkg.New(codes.Internal, "internal error")
+
+	var first int32
+	for i := 0; i < 20; i++ {
+		got := grpcstatus.FromGRPCStatus(s)
+		var gotErr *errs.Error
+		require.ErrorAs(t, got, &gotErr)
+		if i == 0 {
+			first = gotErr.Code
+		} else {
+			assert.Equal(t, first, gotErr.Code)
+		}
+	}
+	assert.EqualValues(t, errs.RetServerDecodeFail, first)
+}
@@ -0,0 +1,112 @@
+package errs
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+)
+
+// httpStatusMu guards httpStatusMapping.
+var httpStatusMu sync.RWMutex
+
+// httpStatusMapping is the built-in Ret code to HTTP status mapping,
+// overridable through RegisterHTTPStatusMapping.
+var httpStatusMapping = map[int]int{
+	RetClientTimeout:         http.StatusGatewayTimeout,
+	RetServerTimeout:         http.StatusGatewayTimeout,
+	RetClientFullLinkTimeout: http.StatusGatewayTimeout,
+	RetServerFullLinkTimeout: http.StatusGatewayTimeout,
+	RetServerThrottled:       http.StatusTooManyRequests,
+	RetServerOverload:        http.StatusTooManyRequests,
+	RetClientThrottled:       http.StatusTooManyRequests,
+	RetClientOverload:        http.StatusTooManyRequests,
+	RetServerAuthFail:        http.StatusUnauthorized,
+	RetInvalidArgument:       http.StatusBadRequest,
+	RetServerValidateFail:    http.StatusBadRequest,
+	RetClientValidateFail:    http.StatusBadRequest,
+	RetNotFound:              http.StatusNotFound,
+	RetServerNoFunc:          http.StatusNotImplemented,
+	RetServerNoService:       http.StatusNotImplemented,
+}
+
+// RegisterHTTPStatusMapping overrides (or adds) the HTTP status code that
+// retCode maps to, so business codes can be slotted into the table
+// alongside the built-in framework codes.
+func RegisterHTTPStatusMapping(retCode, httpStatus int) {
+	httpStatusMu.Lock()
+	defer httpStatusMu.Unlock()
+	httpStatusMapping[retCode] = httpStatus
+}
+
+// HTTPStatus maps err's Code to an HTTP status code, defaulting to 500 for
+// codes with no registered mapping or when err is not an *Error.
+func HTTPStatus(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	httpStatusMu.RLock()
+	defer httpStatusMu.RUnlock()
+	if status, ok := httpStatusMapping[Code(err)]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// errorJSON is the wire shape produced and consumed by (*Error).MarshalJSON
+// and (*Error).UnmarshalJSON.
+type errorJSON struct {
+	Type    int           `json:"type"`
+	Code    int32         `json:"code"`
+	Msg     string        `json:"msg"`
+	Desc    string        `json:"desc,omitempty"`
+	Details []interface{} `json:"details,omitempty"`
+}
+
+// MarshalJSON implements the json.Marshaler interface, so an *Error can be
+// serialized directly by HTTP gateways.
+func (e *Error) MarshalJSON() ([]byte, error) {
+	if e == nil {
+		return json.Marshal(errorJSON{})
+	}
+	return json.Marshal(errorJSON{
+		Type:    e.Type,
+		Code:    e.Code,
+		Msg:     e.Msg,
+		Desc:    e.Desc,
+		Details: e.details,
+	})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (e *Error) UnmarshalJSON(data []byte) error {
+	var parsed errorJSON
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return err
+	}
+	e.Type = parsed.Type
+	e.Code = parsed.Code
+	e.Msg = parsed.Msg
+	e.Desc = parsed.Desc
+	e.details = parsed.Details
+	return nil
+}
+
+// WriteHTTP writes err to w as a JSON body, setting the status code
+// returned by HTTPStatus(err) and a Content-Type of application/json. A nil
+// err is written with HTTPStatus's 200 status and a JSON null body.
+func WriteHTTP(w http.ResponseWriter, err error) {
+	if err == nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode((*Error)(nil))
+		return
+	}
+	var e *Error
+	if !errors.As(err, &e) {
+		e = &Error{Type: ErrorTypeBusiness, Code: int32(RetUnknown), Msg: err.Error()}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(HTTPStatus(err))
+	_ = json.NewEncoder(w).Encode(e)
+}
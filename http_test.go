@@ -0,0 +1,75 @@
+package errs_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/HildaM/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPStatus(t *testing.T) {
+	assert.Equal(t, http.StatusGatewayTimeout, errs.HTTPStatus(errs.New(errs.RetClientTimeout, "timeout")))
+	assert.Equal(t, http.StatusTooManyRequests, errs.HTTPStatus(errs.New(errs.RetServerThrottled, "throttled")))
+	assert.Equal(t, http.StatusBadRequest, errs.HTTPStatus(errs.New(errs.RetInvalidArgument, "bad arg")))
+	assert.Equal(t, http.StatusNotFound, errs.HTTPStatus(errs.New(errs.RetNotFound, "missing")))
+	assert.Equal(t, http.StatusInternalServerError, errs.HTTPStatus(errs.New(999999, "unmapped")))
+	assert.Equal(t, http.StatusOK, errs.HTTPStatus(nil))
+}
+
+func TestRegisterHTTPStatusMapping(t *testing.T) {
+	const code = 424242
+	errs.RegisterHTTPStatusMapping(code, http.StatusTeapot)
+	assert.Equal(t, http.StatusTeapot, errs.HTTPStatus(errs.New(code, "teapot")))
+}
+
+func TestErrorJSON(t *testing.T) {
+	e := errs.New(errs.RetInvalidArgument, "bad arg").(*errs.Error)
+	e.Desc = "validation"
+
+	data, err := json.Marshal(e)
+	require.NoError(t, err)
+
+	var decoded errs.Error
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, e.Type, decoded.Type)
+	assert.Equal(t, e.Code, decoded.Code)
+	assert.Equal(t, e.Msg, decoded.Msg)
+	assert.Equal(t, e.Desc, decoded.Desc)
+}
+
+func TestWriteHTTP(t *testing.T) {
+	rec := httptest.NewRecorder()
+	errs.WriteHTTP(rec, errs.New(errs.RetNotFound, "missing"))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var decoded errs.Error
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &decoded))
+	assert.Equal(t, "missing", decoded.Msg)
+}
+
+func TestWriteHTTPWrappedError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	errs.WriteHTTP(rec, fmt.Errorf("lookup: %w", errs.New(errs.RetNotFound, "missing")))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	var decoded errs.Error
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &decoded))
+	assert.Equal(t, int32(errs.RetNotFound), decoded.Code)
+	assert.Equal(t, "missing", decoded.Msg)
+}
+
+func TestWriteHTTPNilError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	assert.NotPanics(t, func() {
+		errs.WriteHTTP(rec, nil)
+	})
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
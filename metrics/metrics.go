@@ -0,0 +1,68 @@
+// Package metrics provides a ready-made errs.RegisterHook hook that reports
+// Prometheus metrics for every error minted through the errs package.
+package metrics
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/HildaM/errs"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// errorsTotal counts every error minted through errs, labeled by its Type
+// and Code.
+var errorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "errs_errors_total",
+	Help: "Total number of errors minted through the errs package, labeled by type and code.",
+}, []string{"type", "code"})
+
+// wrapDepth records how many errors deep each minted error's cause chain
+// goes, which helps spot excessive re-wrapping.
+var wrapDepth = prometheus.NewHistogram(prometheus.HistogramOpts{
+	Name:    "errs_wrap_depth",
+	Help:    "Depth of the cause chain of errors minted through the errs package.",
+	Buckets: prometheus.LinearBuckets(0, 1, 6),
+})
+
+// Register registers the package's collectors with reg. Call it once at
+// process start, e.g. metrics.Register(prometheus.DefaultRegisterer).
+func Register(reg prometheus.Registerer) error {
+	if err := reg.Register(errorsTotal); err != nil {
+		return err
+	}
+	return reg.Register(wrapDepth)
+}
+
+// Hook is an errs.RegisterHook callback that increments errs_errors_total
+// and observes errs_wrap_depth for every error minted through errs. Install
+// it once at process start:
+//
+//	errs.RegisterHook(metrics.Hook)
+func Hook(e *errs.Error) {
+	typ := "business"
+	switch e.Type {
+	case errs.ErrorTypeFramework:
+		typ = "framework"
+	case errs.ErrorTypeCalleeFramework:
+		typ = "callee_framework"
+	}
+	errorsTotal.WithLabelValues(typ, strconv.Itoa(int(e.Code))).Inc()
+	wrapDepth.Observe(float64(depth(e)))
+}
+
+// depth counts how many *Error values are chained under e, including e
+// itself.
+func depth(e *errs.Error) int {
+	n := 0
+	var cur error = e
+	for cur != nil {
+		n++
+		var next *errs.Error
+		if !errors.As(errors.Unwrap(cur), &next) {
+			break
+		}
+		cur = next
+	}
+	return n
+}